@@ -2,50 +2,58 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"os"
 	"os/signal"
 	"strings"
 	"syscall"
-	"time"
 
 	"emma/internal/config"
-	"emma/internal/handlers"
-	"emma/internal/kafka"
+	"emma/internal/metrics"
+	"emma/internal/sink"
+	"emma/internal/sourcemanager"
+	"emma/internal/telemetry"
+)
+
+const (
+	sinksConfigPath = "./config/sinks.yaml"
+	sourcesDir      = "./sources/"
 )
 
 func main() {
 	log.Println("Starting Data Ingestor...")
 
-	// Load source configurations
-	sources, err := config.LoadSourceConfigs("./sources/")
+	ctx := context.Background()
+
+	shutdownTelemetry, err := telemetry.Init(ctx)
 	if err != nil {
-		log.Fatalf("Failed to load source configs: %v", err)
+		log.Fatalf("Failed to initialize telemetry: %v", err)
 	}
+	defer shutdownTelemetry(ctx)
 
-	if len(sources) == 0 {
-		log.Fatal("No source configurations found in ./sources/")
+	// Build output sinks
+	out, err := buildSinks()
+	if err != nil {
+		log.Fatalf("Failed to initialize sinks: %v", err)
 	}
+	defer out.Close()
 
-	log.Printf("Loaded %d source configurations", len(sources))
+	go serveMetrics()
 
-	// Initialize Kafka producer
-	kafkaConfig := kafka.ProducerConfig{
-		Brokers: getKafkaBrokers(),
-		Topic:   getKafkaTopic(),
+	// The source manager loads ./sources/, watches it for changes, and
+	// owns the worker goroutine backing each source for the rest of the
+	// process's life.
+	mgr := sourcemanager.New(sourcesDir, out)
+	if err := mgr.Start(); err != nil {
+		log.Fatalf("Failed to start source manager: %v", err)
 	}
+	defer mgr.Stop()
 
-	producer, err := kafka.NewProducer(kafkaConfig)
-	if err != nil {
-		log.Fatalf("Failed to create Kafka producer: %v", err)
-	}
-	defer producer.Close()
-
-	// Start workers for each source
-	for _, source := range sources {
-		go startWorker(source, producer)
-	}
+	go serveAdmin(mgr)
 
 	// Wait for interrupt signal
 	sigChan := make(chan os.Signal, 1)
@@ -58,80 +66,67 @@ func main() {
 	log.Println("🛑 Shutting down gracefully...")
 }
 
-func startWorker(source config.SourceConfig, producer *kafka.Producer) {
-	log.Printf("Starting worker for source: %s (type: %s, category: %s)", source.Name, source.Type, source.Category)
-
-	// Get handler for this source type
-	handler, err := handlers.GetHandler(source.Type)
-	if err != nil {
-		log.Printf("Failed to get handler for %s: %v", source.Name, err)
-		return
-	}
-
-	// Validate configuration
-	if err := handler.Validate(source.Config); err != nil {
-		log.Printf("Invalid config for %s: %v", source.Name, err)
-		return
-	}
-
-	// Parse frequency
-	frequency, err := source.GetFrequency()
-	if err != nil {
-		log.Printf("Invalid frequency for %s: %v", source.Name, err)
-		return
+// serveMetrics mounts the Prometheus /metrics endpoint on METRICS_ADDR
+// (default :9090).
+func serveMetrics() {
+	addr := os.Getenv("METRICS_ADDR")
+	if addr == "" {
+		addr = ":9090"
 	}
-
-	// Add category to config for handler
-	sourceConfig := make(map[string]interface{})
-	for k, v := range source.Config {
-		sourceConfig[k] = v
-	}
-	sourceConfig["category"] = source.Category
-	sourceConfig["source"] = source.Name
-
-	// Start periodic fetching
-	ticker := time.NewTicker(frequency)
-	defer ticker.Stop()
-
-	// Fetch immediately on start
-	fetchAndPublish(source.Name, handler, sourceConfig, producer)
-
-	// Then fetch on schedule
-	for range ticker.C {
-		fetchAndPublish(source.Name, handler, sourceConfig, producer)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", metrics.Handler())
+	log.Printf("Serving metrics on %s/metrics", addr)
+	if err := http.ListenAndServe(addr, mux); err != nil {
+		log.Printf("metrics server stopped: %v", err)
 	}
 }
 
-func fetchAndPublish(sourceName string, handler handlers.Handler, config map[string]interface{}, producer *kafka.Producer) {
-	log.Printf("Fetching data for source: %s", sourceName)
-
-	points, err := handler.Fetch(config)
-	if err != nil {
-		log.Printf("Error fetching data for %s: %v", sourceName, err)
-		return
-	}
-
-	if len(points) == 0 {
-		log.Printf("No data points received for %s", sourceName)
-		return
-	}
-
-	log.Printf("Successfully fetched %d data points for %s", len(points), sourceName)
-
-	// Print the data (for debugging)
-	for _, point := range points {
-		fmt.Printf("📊 %s (%s): %s = %.2f %s at (%.4f, %.4f)\n",
-			point.Source, point.Category, point.Variable, point.Value, point.Units, point.Lat, point.Lon)
+// serveAdmin mounts the source lifecycle admin API on ADMIN_ADDR
+// (default :9091).
+func serveAdmin(mgr *sourcemanager.Manager) {
+	addr := os.Getenv("ADMIN_ADDR")
+	if addr == "" {
+		addr = ":9091"
 	}
-
-	// Publish to Kafka
-	err = producer.PublishPoints(points)
-	if err != nil {
-		log.Printf("Failed to publish data for %s: %v", sourceName, err)
-		return
+	log.Printf("Serving admin API on %s", addr)
+	if err := http.ListenAndServe(addr, mgr.AdminHandler()); err != nil {
+		log.Printf("admin server stopped: %v", err)
 	}
+}
 
-	log.Printf("✅ Successfully published %d points for %s", len(points), sourceName)
+// buildSinks loads ./config/sinks.yaml and constructs the fan-out MultiSink.
+// When no sinks file is present, it falls back to a single Kafka sink
+// configured via KAFKA_BROKERS/KAFKA_TOPIC, preserving prior behavior.
+func buildSinks() (*sink.MultiSink, error) {
+	sinkConfigs, err := config.LoadSinkConfigs(sinksConfigPath)
+	if errors.Is(err, os.ErrNotExist) {
+		log.Printf("No sinks config found at %s, falling back to a single Kafka sink", sinksConfigPath)
+		sinkConfigs = []config.SinkConfig{{
+			Name: "kafka",
+			Type: "kafka",
+			Config: map[string]any{
+				"brokers":              strings.Join(getKafkaBrokers(), ","),
+				"topic":                getKafkaTopic(),
+				"schema_registry_url":  os.Getenv("SCHEMA_REGISTRY_URL"),
+				"schema_registry_auth": os.Getenv("SCHEMA_REGISTRY_AUTH"),
+				"serialization_format": getSerializationFormat(),
+			},
+		}}
+	} else if err != nil {
+		return nil, err
+	}
+
+	sinks := make(map[string]sink.Sink, len(sinkConfigs))
+	for _, cfg := range sinkConfigs {
+		s, err := sink.New(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build sink %s: %w", cfg.Name, err)
+		}
+		sinks[cfg.Name] = s
+		log.Printf("✅ Initialized %s sink: %s", cfg.Type, cfg.Name)
+	}
+
+	return sink.NewMultiSink(sinks), nil
 }
 
 func getKafkaBrokers() []string {
@@ -149,3 +144,11 @@ func getKafkaTopic() string {
 	}
 	return topic
 }
+
+func getSerializationFormat() string {
+	format := os.Getenv("SERIALIZATION_FORMAT")
+	if format == "" {
+		format = "protobuf"
+	}
+	return format
+}
@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SinkConfig describes one output destination that published DataPoints
+// should be fanned out to.
+type SinkConfig struct {
+	Name   string         `yaml:"name"`
+	Type   string         `yaml:"type"`
+	Config map[string]any `yaml:"config"`
+}
+
+func (s *SinkConfig) Validate() error {
+	if s.Name == "" {
+		return fmt.Errorf("sink name cannot be empty")
+	}
+	if s.Type == "" {
+		return fmt.Errorf("sink type cannot be empty")
+	}
+	switch s.Type {
+	case "kafka", "influxdb", "minio_parquet", "stdout":
+	default:
+		return fmt.Errorf("invalid sink type '%s'. Valid types are: kafka, influxdb, minio_parquet, stdout", s.Type)
+	}
+	return nil
+}
+
+type sinksFile struct {
+	Sinks []SinkConfig `yaml:"sinks"`
+}
+
+// LoadSinkConfigs reads the top-level sinks file declaring every output
+// destination the ingestor should fan its published points out to.
+func LoadSinkConfigs(path string) ([]SinkConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var file sinksFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	for i := range file.Sinks {
+		if err := file.Sinks[i].Validate(); err != nil {
+			return nil, fmt.Errorf("invalid sink configuration in %s: %w", path, err)
+		}
+	}
+
+	return file.Sinks, nil
+}
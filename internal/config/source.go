@@ -31,11 +31,48 @@ func ValidateCategory(category string) bool {
 }
 
 type SourceConfig struct {
-	Name      string         `yaml:"name"`
-	Type      string         `yaml:"type"`
-	Category  string         `yaml:"category"`
-	Frequency string         `yaml:"frequency"`
-	Config    map[string]any `yaml:"config"`
+	Name           string                `yaml:"name"`
+	Type           string                `yaml:"type"`
+	Category       string                `yaml:"category"`
+	Frequency      string                `yaml:"frequency"`
+	Config         map[string]any        `yaml:"config"`
+	Retry          *RetryConfig          `yaml:"retry,omitempty"`
+	RateLimit      *RateLimitConfig      `yaml:"rate_limit,omitempty"`
+	CircuitBreaker *CircuitBreakerConfig `yaml:"circuit_breaker,omitempty"`
+}
+
+// RetryConfig governs how many times and how aggressively a failed fetch is
+// retried before giving up for the tick.
+type RetryConfig struct {
+	MaxAttempts    int    `yaml:"max_attempts"`
+	InitialBackoff string `yaml:"initial_backoff"`
+	MaxBackoff     string `yaml:"max_backoff"`
+	Jitter         bool   `yaml:"jitter"`
+}
+
+func (r *RetryConfig) InitialBackoffDuration() (time.Duration, error) {
+	return time.ParseDuration(r.InitialBackoff)
+}
+
+func (r *RetryConfig) MaxBackoffDuration() (time.Duration, error) {
+	return time.ParseDuration(r.MaxBackoff)
+}
+
+// RateLimitConfig token-bucket-limits how often a source may be fetched.
+type RateLimitConfig struct {
+	RPS   float64 `yaml:"rps"`
+	Burst int     `yaml:"burst"`
+}
+
+// CircuitBreakerConfig trips a source's breaker after consecutive failures,
+// giving the upstream a cooldown period before probing it again.
+type CircuitBreakerConfig struct {
+	FailureThreshold int    `yaml:"failure_threshold"`
+	Cooldown         string `yaml:"cooldown"`
+}
+
+func (c *CircuitBreakerConfig) CooldownDuration() (time.Duration, error) {
+	return time.ParseDuration(c.Cooldown)
 }
 
 func (s *SourceConfig) GetFrequency() (time.Duration, error) {
@@ -65,6 +102,33 @@ func (s *SourceConfig) Validate() error {
 	if _, err := s.GetFrequency(); err != nil {
 		return fmt.Errorf("invalid frequency format '%s': %w", s.Frequency, err)
 	}
+	if s.Retry != nil {
+		if s.Retry.MaxAttempts < 1 {
+			return fmt.Errorf("retry.max_attempts must be at least 1")
+		}
+		if _, err := s.Retry.InitialBackoffDuration(); err != nil {
+			return fmt.Errorf("invalid retry.initial_backoff '%s': %w", s.Retry.InitialBackoff, err)
+		}
+		if _, err := s.Retry.MaxBackoffDuration(); err != nil {
+			return fmt.Errorf("invalid retry.max_backoff '%s': %w", s.Retry.MaxBackoff, err)
+		}
+	}
+	if s.CircuitBreaker != nil {
+		if s.CircuitBreaker.FailureThreshold < 1 {
+			return fmt.Errorf("circuit_breaker.failure_threshold must be at least 1")
+		}
+		if _, err := s.CircuitBreaker.CooldownDuration(); err != nil {
+			return fmt.Errorf("invalid circuit_breaker.cooldown '%s': %w", s.CircuitBreaker.Cooldown, err)
+		}
+	}
+	if s.RateLimit != nil {
+		if s.RateLimit.RPS <= 0 {
+			return fmt.Errorf("rate_limit.rps must be greater than 0")
+		}
+		if s.RateLimit.Burst < 1 {
+			return fmt.Errorf("rate_limit.burst must be at least 1")
+		}
+	}
 	return nil
 }
 
@@ -9,11 +9,9 @@ func GetHandler(handlerType string) (Handler, error) {
 	case "http_fetch":
 		return NewHTTPFetcher(), nil
 	case "web_scraper":
-		// TODO: Implement web scraper
-		return nil, fmt.Errorf("web_scraper not implemented yet")
+		return NewWebScraper(), nil
 	case "ftp_download":
-		// TODO: Implement FTP downloader
-		return nil, fmt.Errorf("ftp_download not implemented yet")
+		return NewFTPDownloader(), nil
 	default:
 		return nil, fmt.Errorf("unknown handler type: %s", handlerType)
 	}
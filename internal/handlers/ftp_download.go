@@ -0,0 +1,624 @@
+package handlers
+
+import (
+	"bufio"
+	"compress/bzip2"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"emma/gen/go/proto/v1"
+
+	"github.com/jlaffaye/ftp"
+	"github.com/pkg/sftp"
+	"github.com/tidwall/gjson"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/crypto/ssh"
+)
+
+// FTPDownloader fetches files from an FTP or SFTP server, tracks which files
+// have already been ingested via an on-disk cursor, and parses each new file
+// into DataPoints using a configurable column-to-field mapping.
+type FTPDownloader struct{}
+
+func NewFTPDownloader() *FTPDownloader {
+	return &FTPDownloader{}
+}
+
+// remoteFile is a protocol-agnostic view of a listed remote entry.
+type remoteFile struct {
+	Name    string
+	ModTime time.Time
+}
+
+// cursorState tracks, per remote filename, the mtime we last ingested it at.
+type cursorState struct {
+	Files map[string]time.Time `json:"files"`
+}
+
+// fieldMapping maps one column/path of a parsed record onto a DataPoint field.
+type fieldMapping struct {
+	Field    string `yaml:"field"`
+	Column   string `yaml:"column"`
+	XPath    string `yaml:"xpath"`
+	JSONPath string `yaml:"json_path"`
+}
+
+func (h *FTPDownloader) Validate(config map[string]interface{}) error {
+	if _, ok := config["host"]; !ok {
+		return fmt.Errorf("host is required for ftp_download")
+	}
+	if _, ok := config["path_pattern"]; !ok {
+		return fmt.Errorf("path_pattern is required for ftp_download")
+	}
+	parser, ok := config["parser"].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("parser is required for ftp_download")
+	}
+	switch h.getStringConfig(parser, "type", "") {
+	case "csv", "xml", "jsonlines":
+	default:
+		return fmt.Errorf("parser.type must be one of csv, xml, jsonlines")
+	}
+	return nil
+}
+
+func (h *FTPDownloader) Fetch(ctx context.Context, config map[string]interface{}) ([]*v1.DataPoint, error) {
+	protocol := h.getStringConfig(config, "protocol", "ftp")
+
+	ctx, span := tracer.Start(ctx, "ftp_download.Fetch", trace.WithAttributes(
+		attribute.String("handler.type", "ftp_download"),
+	))
+	defer span.End()
+
+	host := h.getStringConfig(config, "host", "")
+	port := h.getStringConfig(config, "port", h.defaultPort(protocol))
+	username := h.resolveEnv(h.getStringConfig(config, "username", ""))
+	password := h.resolveEnv(h.getStringConfig(config, "password", ""))
+	pathPattern := h.getStringConfig(config, "path_pattern", "")
+	dir := filepath.Dir(pathPattern)
+	pattern := filepath.Base(pathPattern)
+
+	var files []remoteFile
+	var err error
+
+	switch protocol {
+	case "ftp":
+		files, err = h.listFTP(ctx, host, port, username, password, dir, pattern)
+	case "sftp":
+		files, err = h.listSFTP(ctx, host, port, username, password, dir, pattern)
+	default:
+		return nil, fmt.Errorf("unsupported protocol %q, must be ftp or sftp", protocol)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list remote files: %w", err)
+	}
+
+	cursorPath := h.getStringConfig(config, "cursor_path", filepath.Join("./state", h.getStringConfig(config, "source", "ftp")+".json"))
+	cursor, err := h.loadCursor(cursorPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load cursor: %w", err)
+	}
+
+	// Process oldest-first so a crash mid-batch resumes cleanly.
+	sort.Slice(files, func(i, j int) bool { return files[i].ModTime.Before(files[j].ModTime) })
+
+	var points []*v1.DataPoint
+	for _, file := range files {
+		seen, ok := cursor.Files[file.Name]
+		if ok && !file.ModTime.After(seen) {
+			continue
+		}
+
+		var raw []byte
+		switch protocol {
+		case "ftp":
+			raw, err = h.downloadFTP(ctx, host, port, username, password, filepath.Join(dir, file.Name))
+		case "sftp":
+			raw, err = h.downloadSFTP(ctx, host, port, username, password, filepath.Join(dir, file.Name))
+		}
+		if err != nil {
+			return points, fmt.Errorf("failed to download %s: %w", file.Name, err)
+		}
+
+		raw, err = h.decompress(raw, file.Name, h.getStringConfig(config, "compression", "auto"))
+		if err != nil {
+			return points, fmt.Errorf("failed to decompress %s: %w", file.Name, err)
+		}
+
+		filePoints, err := h.parseFile(raw, config)
+		if err != nil {
+			return points, fmt.Errorf("failed to parse %s: %w", file.Name, err)
+		}
+		points = append(points, filePoints...)
+
+		// Persist the cursor after every file, not just at the end of the
+		// batch, so a later file failing doesn't undo progress already made
+		// on the files before it.
+		cursor.Files[file.Name] = file.ModTime
+		if err := h.saveCursor(cursorPath, cursor); err != nil {
+			return points, fmt.Errorf("failed to persist cursor after %s: %w", file.Name, err)
+		}
+	}
+
+	span.SetAttributes(attribute.Int("points.count", len(points)))
+	return points, nil
+}
+
+func (h *FTPDownloader) defaultPort(protocol string) string {
+	if protocol == "sftp" {
+		return "22"
+	}
+	return "21"
+}
+
+func (h *FTPDownloader) listFTP(ctx context.Context, host, port, username, password, dir, pattern string) ([]remoteFile, error) {
+	conn, err := ftp.Dial(fmt.Sprintf("%s:%s", host, port), ftp.DialWithContext(ctx), ftp.DialWithTimeout(30*time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("dial failed: %w", err)
+	}
+	defer conn.Quit()
+
+	stop := watchCancel(ctx, func() { conn.Quit() })
+	defer close(stop)
+
+	if username != "" {
+		if err := conn.Login(username, password); err != nil {
+			return nil, fmt.Errorf("login failed: %w", err)
+		}
+	}
+
+	entries, err := conn.List(dir)
+	if err != nil {
+		return nil, fmt.Errorf("list failed: %w", err)
+	}
+
+	var files []remoteFile
+	for _, entry := range entries {
+		if entry.Type != ftp.EntryTypeFile {
+			continue
+		}
+		matched, err := filepath.Match(pattern, entry.Name)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path_pattern: %w", err)
+		}
+		if matched {
+			files = append(files, remoteFile{Name: entry.Name, ModTime: entry.Time})
+		}
+	}
+	return files, nil
+}
+
+func (h *FTPDownloader) downloadFTP(ctx context.Context, host, port, username, password, path string) ([]byte, error) {
+	conn, err := ftp.Dial(fmt.Sprintf("%s:%s", host, port), ftp.DialWithContext(ctx), ftp.DialWithTimeout(30*time.Second))
+	if err != nil {
+		return nil, fmt.Errorf("dial failed: %w", err)
+	}
+	defer conn.Quit()
+
+	stop := watchCancel(ctx, func() { conn.Quit() })
+	defer close(stop)
+
+	if username != "" {
+		if err := conn.Login(username, password); err != nil {
+			return nil, fmt.Errorf("login failed: %w", err)
+		}
+	}
+
+	resp, err := conn.Retr(path)
+	if err != nil {
+		return nil, fmt.Errorf("retr failed: %w", err)
+	}
+	defer resp.Close()
+
+	return io.ReadAll(resp)
+}
+
+// sftpClient dials the SSH transport via a context-aware net.Dialer so the
+// handshake honors ctx's deadline/cancellation, then layers an SFTP client
+// on top.
+func (h *FTPDownloader) sftpClient(ctx context.Context, host, port, username, password string) (*sftp.Client, *ssh.Client, error) {
+	addr := fmt.Sprintf("%s:%s", host, port)
+	sshConfig := &ssh.ClientConfig{
+		User:            username,
+		Auth:            []ssh.AuthMethod{ssh.Password(password)},
+		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
+		Timeout:         30 * time.Second,
+	}
+
+	dialer := net.Dialer{Timeout: sshConfig.Timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("tcp dial failed: %w", err)
+	}
+
+	stop := watchCancel(ctx, func() { conn.Close() })
+	defer close(stop)
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, addr, sshConfig)
+	if err != nil {
+		conn.Close()
+		return nil, nil, fmt.Errorf("ssh handshake failed: %w", err)
+	}
+	sshClient := ssh.NewClient(sshConn, chans, reqs)
+
+	client, err := sftp.NewClient(sshClient)
+	if err != nil {
+		sshClient.Close()
+		return nil, nil, fmt.Errorf("sftp client failed: %w", err)
+	}
+
+	return client, sshClient, nil
+}
+
+func (h *FTPDownloader) listSFTP(ctx context.Context, host, port, username, password, dir, pattern string) ([]remoteFile, error) {
+	client, sshConn, err := h.sftpClient(ctx, host, port, username, password)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	defer sshConn.Close()
+
+	stop := watchCancel(ctx, func() { client.Close(); sshConn.Close() })
+	defer close(stop)
+
+	entries, err := client.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("readdir failed: %w", err)
+	}
+
+	var files []remoteFile
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		matched, err := filepath.Match(pattern, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("invalid path_pattern: %w", err)
+		}
+		if matched {
+			files = append(files, remoteFile{Name: entry.Name(), ModTime: entry.ModTime()})
+		}
+	}
+	return files, nil
+}
+
+func (h *FTPDownloader) downloadSFTP(ctx context.Context, host, port, username, password, path string) ([]byte, error) {
+	client, sshConn, err := h.sftpClient(ctx, host, port, username, password)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+	defer sshConn.Close()
+
+	stop := watchCancel(ctx, func() { client.Close(); sshConn.Close() })
+	defer close(stop)
+
+	f, err := client.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open failed: %w", err)
+	}
+	defer f.Close()
+
+	return io.ReadAll(f)
+}
+
+// watchCancel runs onCancel if ctx is done before the returned channel is
+// closed, unblocking any in-flight network call tied to the connection
+// onCancel closes. Callers must close the returned channel once the call
+// they're guarding has returned.
+func watchCancel(ctx context.Context, onCancel func()) chan struct{} {
+	stop := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			onCancel()
+		case <-stop:
+		}
+	}()
+	return stop
+}
+
+func (h *FTPDownloader) decompress(raw []byte, filename, mode string) ([]byte, error) {
+	if mode == "auto" {
+		switch {
+		case strings.HasSuffix(filename, ".gz"):
+			mode = "gzip"
+		case strings.HasSuffix(filename, ".bz2"):
+			mode = "bzip2"
+		default:
+			mode = "none"
+		}
+	}
+
+	switch mode {
+	case "none", "":
+		return raw, nil
+	case "gzip":
+		r, err := gzip.NewReader(strings.NewReader(string(raw)))
+		if err != nil {
+			return nil, fmt.Errorf("gzip reader: %w", err)
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	case "bzip2":
+		return io.ReadAll(bzip2.NewReader(strings.NewReader(string(raw))))
+	default:
+		return nil, fmt.Errorf("unsupported compression %q", mode)
+	}
+}
+
+func (h *FTPDownloader) parseFile(raw []byte, config map[string]interface{}) ([]*v1.DataPoint, error) {
+	source := h.getStringConfig(config, "source", "unknown")
+	category := h.getStringConfig(config, "category", "environmental")
+
+	parserConfig, _ := config["parser"].(map[string]interface{})
+	mappings := h.parseFieldMappings(parserConfig)
+
+	switch h.getStringConfig(parserConfig, "type", "") {
+	case "csv":
+		return h.parseCSV(raw, parserConfig, mappings, source, category)
+	case "xml":
+		return h.parseXML(raw, parserConfig, mappings, source, category)
+	case "jsonlines":
+		return h.parseJSONLines(raw, mappings, source, category)
+	default:
+		return nil, fmt.Errorf("unsupported parser type")
+	}
+}
+
+func (h *FTPDownloader) parseFieldMappings(parserConfig map[string]interface{}) []fieldMapping {
+	var mappings []fieldMapping
+	fields, _ := parserConfig["fields"].([]interface{})
+	for _, raw := range fields {
+		f, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		mappings = append(mappings, fieldMapping{
+			Field:    h.getStringConfig(f, "field", ""),
+			Column:   h.getStringConfig(f, "column", ""),
+			XPath:    h.getStringConfig(f, "xpath", ""),
+			JSONPath: h.getStringConfig(f, "json_path", ""),
+		})
+	}
+	return mappings
+}
+
+func (h *FTPDownloader) parseCSV(raw []byte, parserConfig map[string]interface{}, mappings []fieldMapping, source, category string) ([]*v1.DataPoint, error) {
+	delimiter := h.getStringConfig(parserConfig, "delimiter", ",")
+
+	reader := csv.NewReader(strings.NewReader(string(raw)))
+	reader.Comma = rune(delimiter[0])
+
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("csv parse failed: %w", err)
+	}
+	if len(rows) < 2 {
+		return nil, nil
+	}
+
+	header := rows[0]
+	columnIndex := make(map[string]int, len(header))
+	for i, name := range header {
+		columnIndex[name] = i
+	}
+
+	var points []*v1.DataPoint
+	for _, row := range rows[1:] {
+		record := make(map[string]string, len(mappings))
+		for _, m := range mappings {
+			idx, ok := columnIndex[m.Column]
+			if !ok || idx >= len(row) {
+				continue
+			}
+			record[m.Field] = row[idx]
+		}
+		point, err := h.buildDataPoint(record, source, category)
+		if err != nil {
+			return nil, err
+		}
+		if point != nil {
+			points = append(points, point)
+		}
+	}
+	return points, nil
+}
+
+func (h *FTPDownloader) parseXML(raw []byte, parserConfig map[string]interface{}, mappings []fieldMapping, source, category string) ([]*v1.DataPoint, error) {
+	rowElement := h.getStringConfig(parserConfig, "row_element", "")
+	if rowElement == "" {
+		return nil, fmt.Errorf("parser.row_element is required for xml parsing")
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(string(raw)))
+
+	var points []*v1.DataPoint
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("xml decode failed: %w", err)
+		}
+
+		start, ok := tok.(xml.StartElement)
+		if !ok || start.Name.Local != rowElement {
+			continue
+		}
+
+		var raw struct {
+			XMLName  xml.Name
+			Elements []struct {
+				XMLName xml.Name
+				Value   string `xml:",chardata"`
+			} `xml:",any"`
+		}
+		if err := decoder.DecodeElement(&raw, &start); err != nil {
+			return nil, fmt.Errorf("xml decode row failed: %w", err)
+		}
+
+		record := make(map[string]string, len(mappings))
+		fieldByXPath := make(map[string]string, len(raw.Elements))
+		for _, el := range raw.Elements {
+			fieldByXPath[el.XMLName.Local] = strings.TrimSpace(el.Value)
+		}
+		for _, m := range mappings {
+			if val, ok := fieldByXPath[m.XPath]; ok {
+				record[m.Field] = val
+			}
+		}
+
+		point, err := h.buildDataPoint(record, source, category)
+		if err != nil {
+			return nil, err
+		}
+		if point != nil {
+			points = append(points, point)
+		}
+	}
+	return points, nil
+}
+
+func (h *FTPDownloader) parseJSONLines(raw []byte, mappings []fieldMapping, source, category string) ([]*v1.DataPoint, error) {
+	var points []*v1.DataPoint
+
+	scanner := bufio.NewScanner(strings.NewReader(string(raw)))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if !json.Valid([]byte(line)) {
+			return nil, fmt.Errorf("invalid json line: %s", line)
+		}
+
+		record := make(map[string]string, len(mappings))
+		for _, m := range mappings {
+			result := gjson.Get(line, m.JSONPath)
+			if result.Exists() {
+				record[m.Field] = result.String()
+			}
+		}
+
+		point, err := h.buildDataPoint(record, source, category)
+		if err != nil {
+			return nil, err
+		}
+		if point != nil {
+			points = append(points, point)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan failed: %w", err)
+	}
+	return points, nil
+}
+
+func (h *FTPDownloader) buildDataPoint(record map[string]string, source, category string) (*v1.DataPoint, error) {
+	valueStr, ok := record["value"]
+	if !ok {
+		return nil, nil
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q: %w", valueStr, err)
+	}
+
+	var lat, lon float64
+	if v, ok := record["lat"]; ok {
+		if lat, err = strconv.ParseFloat(v, 64); err != nil {
+			return nil, fmt.Errorf("invalid lat %q: %w", v, err)
+		}
+	}
+	if v, ok := record["lon"]; ok {
+		if lon, err = strconv.ParseFloat(v, 64); err != nil {
+			return nil, fmt.Errorf("invalid lon %q: %w", v, err)
+		}
+	}
+
+	stationID := record["station_id"]
+	uuid := h.generateUUID(source, record["variable"], stationID)
+
+	return &v1.DataPoint{
+		Source:     source,
+		EpochMs:    time.Now().UnixMilli(),
+		Value:      value,
+		Lat:        lat,
+		Lon:        lon,
+		Variable:   record["variable"],
+		Units:      record["units"],
+		Resolution: "point",
+		Uuid:       uuid,
+		Category:   category,
+	}, nil
+}
+
+func (h *FTPDownloader) generateUUID(source, variable, stationId string) string {
+	timestamp := time.Now().UnixNano()
+	if stationId != "" {
+		return fmt.Sprintf("%s_%s_%s_%d", source, variable, stationId, timestamp)
+	}
+	return fmt.Sprintf("%s_%s_%d", source, variable, timestamp)
+}
+
+func (h *FTPDownloader) loadCursor(path string) (*cursorState, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &cursorState{Files: make(map[string]time.Time)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var state cursorState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse cursor file %s: %w", path, err)
+	}
+	if state.Files == nil {
+		state.Files = make(map[string]time.Time)
+	}
+	return &state, nil
+}
+
+func (h *FTPDownloader) saveCursor(path string, state *cursorState) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o644)
+}
+
+func (h *FTPDownloader) resolveEnv(value string) string {
+	if strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}") {
+		envVar := strings.TrimPrefix(strings.TrimSuffix(value, "}"), "${")
+		return os.Getenv(envVar)
+	}
+	return value
+}
+
+func (h *FTPDownloader) getStringConfig(config map[string]interface{}, key, defaultValue string) string {
+	if val, ok := config[key].(string); ok {
+		return val
+	}
+	return defaultValue
+}
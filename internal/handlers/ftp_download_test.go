@@ -0,0 +1,113 @@
+package handlers
+
+import "testing"
+
+func TestParseCSV(t *testing.T) {
+	h := NewFTPDownloader()
+	mappings := []fieldMapping{
+		{Field: "value", Column: "temp"},
+		{Field: "variable", Column: "name"},
+	}
+	raw := []byte("name,temp\ntemperature,21.5\n")
+
+	points, err := h.parseCSV(raw, map[string]interface{}{}, mappings, "station-1", "environmental")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(points) != 1 {
+		t.Fatalf("expected 1 point, got %d", len(points))
+	}
+	if points[0].Value != 21.5 {
+		t.Fatalf("expected value 21.5, got %v", points[0].Value)
+	}
+	if points[0].Variable != "temperature" {
+		t.Fatalf("expected variable %q, got %q", "temperature", points[0].Variable)
+	}
+}
+
+func TestParseCSVCustomDelimiter(t *testing.T) {
+	h := NewFTPDownloader()
+	mappings := []fieldMapping{
+		{Field: "value", Column: "temp"},
+	}
+	parserConfig := map[string]interface{}{"delimiter": ";"}
+	raw := []byte("temp;name\n21.5;temperature\n")
+
+	points, err := h.parseCSV(raw, parserConfig, mappings, "station-1", "environmental")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(points) != 1 || points[0].Value != 21.5 {
+		t.Fatalf("expected 1 point with value 21.5, got %+v", points)
+	}
+}
+
+func TestParseCSVHeaderOnlyProducesNoPoints(t *testing.T) {
+	h := NewFTPDownloader()
+	mappings := []fieldMapping{{Field: "value", Column: "temp"}}
+
+	points, err := h.parseCSV([]byte("temp\n"), map[string]interface{}{}, mappings, "station-1", "environmental")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(points) != 0 {
+		t.Fatalf("expected no points for a header-only file, got %d", len(points))
+	}
+}
+
+func TestParseXML(t *testing.T) {
+	h := NewFTPDownloader()
+	mappings := []fieldMapping{
+		{Field: "value", XPath: "temp"},
+		{Field: "variable", XPath: "name"},
+	}
+	parserConfig := map[string]interface{}{"row_element": "reading"}
+	raw := []byte(`<readings><reading><name>temperature</name><temp>21.5</temp></reading></readings>`)
+
+	points, err := h.parseXML(raw, parserConfig, mappings, "station-1", "environmental")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(points) != 1 {
+		t.Fatalf("expected 1 point, got %d", len(points))
+	}
+	if points[0].Value != 21.5 || points[0].Variable != "temperature" {
+		t.Fatalf("unexpected point: %+v", points[0])
+	}
+}
+
+func TestParseXMLMissingRowElement(t *testing.T) {
+	h := NewFTPDownloader()
+	_, err := h.parseXML([]byte(`<readings></readings>`), map[string]interface{}{}, nil, "station-1", "environmental")
+	if err == nil {
+		t.Fatalf("expected error when parser.row_element is not configured")
+	}
+}
+
+func TestParseJSONLines(t *testing.T) {
+	h := NewFTPDownloader()
+	mappings := []fieldMapping{
+		{Field: "value", JSONPath: "temp"},
+		{Field: "variable", JSONPath: "name"},
+	}
+	raw := []byte("{\"name\":\"temperature\",\"temp\":21.5}\n{\"name\":\"humidity\",\"temp\":55}\n")
+
+	points, err := h.parseJSONLines(raw, mappings, "station-1", "environmental")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(points) != 2 {
+		t.Fatalf("expected 2 points, got %d", len(points))
+	}
+	if points[0].Variable != "temperature" || points[1].Variable != "humidity" {
+		t.Fatalf("unexpected points: %+v", points)
+	}
+}
+
+func TestParseJSONLinesInvalidLine(t *testing.T) {
+	h := NewFTPDownloader()
+	_, err := h.parseJSONLines([]byte("not json\n"), nil, "station-1", "environmental")
+	if err == nil {
+		t.Fatalf("expected error for an invalid JSON line")
+	}
+}
@@ -1,10 +1,31 @@
 package handlers
 
 import (
+	"context"
+	"time"
+
 	"emma/gen/go/proto/v1"
 )
 
 type Handler interface {
-	Fetch(config map[string]any) ([]*v1.DataPoint, error)
+	Fetch(ctx context.Context, config map[string]any) ([]*v1.DataPoint, error)
 	Validate(config map[string]any) error
 }
+
+// RetryableError signals that a Fetch failure is transient and worth
+// retrying, optionally carrying an upstream-provided Retry-After delay
+// (e.g. from an HTTP 429/5xx response) that callers should honor instead of
+// their own backoff schedule.
+type RetryableError struct {
+	StatusCode int
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *RetryableError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryableError) Unwrap() error {
+	return e.Err
+}
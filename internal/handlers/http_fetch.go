@@ -1,18 +1,25 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
-	"emma/gen/go/proto"
+	"emma/gen/go/proto/v1"
 
 	"github.com/tidwall/gjson"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
+var tracer = otel.Tracer("emma/internal/handlers")
+
 type HTTPFetcher struct {
 	client *http.Client
 }
@@ -32,12 +39,18 @@ func (h *HTTPFetcher) Validate(config map[string]interface{}) error {
 	return nil
 }
 
-func (h *HTTPFetcher) Fetch(config map[string]interface{}) ([]*proto.DataPoint, error) {
+func (h *HTTPFetcher) Fetch(ctx context.Context, config map[string]interface{}) ([]*v1.DataPoint, error) {
 	url := config["url"].(string)
 	method := h.getStringConfig(config, "method", "GET")
 
+	ctx, span := tracer.Start(ctx, "http_fetch.Fetch", trace.WithAttributes(
+		attribute.String("handler.type", "http_fetch"),
+		attribute.String("http.url", url),
+	))
+	defer span.End()
+
 	// Build request
-	req, err := http.NewRequest(method, url, nil)
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
@@ -81,7 +94,15 @@ func (h *HTTPFetcher) Fetch(config map[string]interface{}) ([]*proto.DataPoint,
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+		err := fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+			return nil, &RetryableError{
+				StatusCode: resp.StatusCode,
+				RetryAfter: parseRetryAfter(resp.Header.Get("Retry-After")),
+				Err:        err,
+			}
+		}
+		return nil, err
 	}
 
 	// Parse JSON response
@@ -93,11 +114,17 @@ func (h *HTTPFetcher) Fetch(config map[string]interface{}) ([]*proto.DataPoint,
 	jsonBytes, _ := json.Marshal(jsonData)
 
 	// Extract data using JSONPath
-	return h.extractDataPoints(string(jsonBytes), config)
+	points, err := h.extractDataPoints(string(jsonBytes), config)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("points.count", len(points)))
+	return points, nil
 }
 
-func (h *HTTPFetcher) extractDataPoints(jsonData string, config map[string]interface{}) ([]*proto.DataPoint, error) {
-	var points []*proto.DataPoint
+func (h *HTTPFetcher) extractDataPoints(jsonData string, config map[string]interface{}) ([]*v1.DataPoint, error) {
+	var points []*v1.DataPoint
 
 	// Get the configuration
 	source := h.getStringConfig(config, "source", "unknown")
@@ -131,7 +158,7 @@ func (h *HTTPFetcher) extractDataPoints(jsonData string, config map[string]inter
 	return points, nil
 }
 
-func (h *HTTPFetcher) extractSingleDataPoint(jsonData string, config map[string]interface{}, source, category string) (*proto.DataPoint, error) {
+func (h *HTTPFetcher) extractSingleDataPoint(jsonData string, config map[string]interface{}, source, category string) (*v1.DataPoint, error) {
 	// Get the response path
 	responsePath := h.getStringConfig(config, "response_path", "")
 	if responsePath == "" {
@@ -167,7 +194,7 @@ func (h *HTTPFetcher) extractSingleDataPoint(jsonData string, config map[string]
 	stationId := h.getStringConfig(config, "station_id", "")
 	uuid := h.generateUUID(source, variable, stationId)
 
-	point := &proto.DataPoint{
+	point := &v1.DataPoint{
 		Source:     source,
 		EpochMs:    time.Now().UnixMilli(),
 		Value:      value,
@@ -237,3 +264,21 @@ func (h *HTTPFetcher) getStringConfig(config map[string]interface{}, key, defaul
 	}
 	return defaultValue
 }
+
+// parseRetryAfter understands both forms of the Retry-After header: a delay
+// in seconds, or an HTTP-date to wait until. It returns 0 if the header is
+// absent or malformed, leaving the caller to fall back to its own backoff.
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		if d := time.Until(when); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
@@ -0,0 +1,257 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"emma/gen/go/proto/v1"
+
+	"github.com/PuerkitoBio/goquery"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// WebScraper fetches an HTML page and extracts repeated rows of data via
+// CSS selectors, for sources that publish HTML tables instead of JSON APIs.
+type WebScraper struct {
+	client *http.Client
+}
+
+func NewWebScraper() *WebScraper {
+	return &WebScraper{
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+func (h *WebScraper) Validate(config map[string]interface{}) error {
+	if _, ok := config["url"]; !ok {
+		return fmt.Errorf("url is required for web_scraper")
+	}
+	if _, ok := config["row_selector"]; !ok {
+		return fmt.Errorf("row_selector is required for web_scraper")
+	}
+	if _, ok := config["fields"]; !ok {
+		return fmt.Errorf("fields is required for web_scraper")
+	}
+	return nil
+}
+
+func (h *WebScraper) Fetch(ctx context.Context, config map[string]interface{}) ([]*v1.DataPoint, error) {
+	url := config["url"].(string)
+	method := h.getStringConfig(config, "method", "GET")
+
+	ctx, span := tracer.Start(ctx, "web_scraper.Fetch", trace.WithAttributes(
+		attribute.String("handler.type", "web_scraper"),
+		attribute.String("http.url", url),
+	))
+	defer span.End()
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if headers, ok := config["headers"].([]interface{}); ok {
+		for _, header := range headers {
+			if hdr, ok := header.(map[string]interface{}); ok {
+				key := hdr["key"].(string)
+				value := h.resolveEnv(hdr["value"].(string))
+				req.Header.Set(key, value)
+			}
+		}
+	}
+
+	if params, ok := config["params"].([]interface{}); ok {
+		q := req.URL.Query()
+		for _, param := range params {
+			if p, ok := param.(map[string]interface{}); ok {
+				key := p["key"].(string)
+				value := h.resolveEnv(p["value"].(string))
+				q.Add(key, value)
+			}
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch page: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	points, err := h.extractDataPoints(doc, config)
+	if err != nil {
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("points.count", len(points)))
+	return points, nil
+}
+
+func (h *WebScraper) extractDataPoints(doc *goquery.Document, config map[string]interface{}) ([]*v1.DataPoint, error) {
+	source := h.getStringConfig(config, "source", "unknown")
+	category := h.getStringConfig(config, "category", "environmental")
+	rowSelector := h.getStringConfig(config, "row_selector", "")
+
+	fieldsConfig, ok := config["fields"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("fields must be a list of field selectors")
+	}
+
+	var points []*v1.DataPoint
+	var extractErr error
+
+	doc.Find(rowSelector).EachWithBreak(func(_ int, row *goquery.Selection) bool {
+		record := make(map[string]string, len(fieldsConfig))
+
+		for _, raw := range fieldsConfig {
+			fieldConfig, ok := raw.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			field := h.getStringConfig(fieldConfig, "field", "")
+			value, err := h.extractField(row, fieldConfig)
+			if err != nil {
+				extractErr = fmt.Errorf("failed to extract field %s: %w", field, err)
+				return false
+			}
+			record[field] = value
+		}
+
+		point, err := h.buildDataPoint(record, source, category)
+		if err != nil {
+			extractErr = err
+			return false
+		}
+		if point != nil {
+			points = append(points, point)
+		}
+		return true
+	})
+
+	if extractErr != nil {
+		return nil, extractErr
+	}
+
+	return points, nil
+}
+
+func (h *WebScraper) extractField(row *goquery.Selection, fieldConfig map[string]interface{}) (string, error) {
+	selector := h.getStringConfig(fieldConfig, "selector", "")
+
+	sel := row
+	if selector != "" {
+		sel = row.Find(selector)
+	}
+
+	attr := h.getStringConfig(fieldConfig, "attr", "")
+	var value string
+	if attr != "" {
+		attrName := strings.TrimPrefix(attr, "@")
+		value, _ = sel.Attr(attrName)
+	} else {
+		value = strings.TrimSpace(sel.Text())
+	}
+
+	if pattern := h.getStringConfig(fieldConfig, "regex", ""); pattern != "" {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return "", fmt.Errorf("invalid regex %q: %w", pattern, err)
+		}
+		matches := re.FindStringSubmatch(value)
+		if len(matches) > 1 {
+			value = matches[1]
+		} else if len(matches) == 1 {
+			value = matches[0]
+		} else {
+			value = ""
+		}
+	}
+
+	if decimalSeparator := h.getStringConfig(fieldConfig, "decimal_separator", ""); decimalSeparator != "" && decimalSeparator != "." {
+		value = strings.ReplaceAll(value, ".", "")
+		value = strings.Replace(value, decimalSeparator, ".", 1)
+	}
+
+	return value, nil
+}
+
+func (h *WebScraper) buildDataPoint(record map[string]string, source, category string) (*v1.DataPoint, error) {
+	valueStr, ok := record["value"]
+	if !ok || valueStr == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value %q: %w", valueStr, err)
+	}
+
+	var lat, lon float64
+	if v, ok := record["lat"]; ok && v != "" {
+		if lat, err = strconv.ParseFloat(v, 64); err != nil {
+			return nil, fmt.Errorf("invalid lat %q: %w", v, err)
+		}
+	}
+	if v, ok := record["lon"]; ok && v != "" {
+		if lon, err = strconv.ParseFloat(v, 64); err != nil {
+			return nil, fmt.Errorf("invalid lon %q: %w", v, err)
+		}
+	}
+
+	stationID := record["station_id"]
+	uuid := h.generateUUID(source, record["variable"], stationID)
+
+	return &v1.DataPoint{
+		Source:     source,
+		EpochMs:    time.Now().UnixMilli(),
+		Value:      value,
+		Lat:        lat,
+		Lon:        lon,
+		Variable:   record["variable"],
+		Units:      record["units"],
+		Resolution: "point",
+		Uuid:       uuid,
+		Category:   category,
+	}, nil
+}
+
+func (h *WebScraper) generateUUID(source, variable, stationId string) string {
+	timestamp := time.Now().UnixNano()
+	if stationId != "" {
+		return fmt.Sprintf("%s_%s_%s_%d", source, variable, stationId, timestamp)
+	}
+	return fmt.Sprintf("%s_%s_%d", source, variable, timestamp)
+}
+
+func (h *WebScraper) resolveEnv(value string) string {
+	if strings.HasPrefix(value, "${") && strings.HasSuffix(value, "}") {
+		envVar := strings.TrimPrefix(strings.TrimSuffix(value, "}"), "${")
+		return os.Getenv(envVar)
+	}
+	return value
+}
+
+func (h *WebScraper) getStringConfig(config map[string]interface{}, key, defaultValue string) string {
+	if val, ok := config[key].(string); ok {
+		return val
+	}
+	return defaultValue
+}
@@ -9,18 +9,45 @@ import (
 	"time"
 
 	"emma/gen/go/proto/v1"
+	"emma/internal/metrics"
+
 	"github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	protobuf "google.golang.org/protobuf/proto"
 )
 
+var tracer = otel.Tracer("emma/internal/kafka")
+
 type Producer struct {
 	writer *kafka.Writer
 	topic  string
+	format string
+	// schemaID is nil when schema registry integration is disabled or
+	// running in degraded mode after a failed registration.
+	schemaID *int
 }
 
 type ProducerConfig struct {
 	Brokers []string
 	Topic   string
+
+	// SchemaRegistryURL, when set, enables Confluent wire format framing:
+	// the DataPoint schema is registered under "<topic>-value" on startup
+	// and every published message is prefixed with the resulting schema id.
+	SchemaRegistryURL string
+	// SchemaRegistryAuth is sent verbatim as the registry's Authorization
+	// header, e.g. "Basic <base64>".
+	SchemaRegistryAuth string
+	// SerializationFormat selects the message payload encoding: "protobuf"
+	// (default) or "json". "avro" is accepted by config but not yet
+	// implemented — no Avro codec exists for DataPoint — and NewProducer
+	// rejects it outright rather than silently falling back.
+	SerializationFormat string
+	// SchemaRegistryFailFast makes NewProducer return an error when the
+	// registry is unreachable instead of falling back to unframed messages.
+	SchemaRegistryFailFast bool
 }
 
 func NewProducer(config ProducerConfig) (*Producer, error) {
@@ -34,30 +61,74 @@ func NewProducer(config ProducerConfig) (*Producer, error) {
 
 	// Connection will be validated on first real message
 
+	format := config.SerializationFormat
+	if format == "" {
+		format = "protobuf"
+	}
+	if format == "avro" {
+		return nil, fmt.Errorf("serialization format %q is not supported yet: no Avro codec is wired up for DataPoint", format)
+	}
+	if format != "protobuf" && format != "json" {
+		return nil, fmt.Errorf("unknown serialization format %q", format)
+	}
+
+	var schemaID *int
+	if config.SchemaRegistryURL != "" {
+		schema, schemaType := dataPointProtoSchema, "PROTOBUF"
+		if format == "json" {
+			schema, schemaType = dataPointJSONSchema, "JSON"
+		}
+
+		client := NewSchemaRegistryClient(config.SchemaRegistryURL, config.SchemaRegistryAuth)
+		subject := fmt.Sprintf("%s-value", config.Topic)
+		id, err := client.Register(subject, schema, schemaType)
+		if err != nil {
+			if config.SchemaRegistryFailFast {
+				return nil, fmt.Errorf("failed to register schema for subject %s: %w", subject, err)
+			}
+			log.Printf("⚠️  Schema registry unreachable, publishing without Confluent framing: %v", err)
+		} else {
+			schemaID = &id
+			log.Printf("✅ Registered DataPoint schema under %s, schema id %d", subject, id)
+		}
+	}
+
 	log.Printf("✅ Connected to Kafka brokers: %v, topic: %s", config.Brokers, config.Topic)
 
 	return &Producer{
-		writer: writer,
-		topic:  config.Topic,
+		writer:   writer,
+		topic:    config.Topic,
+		format:   format,
+		schemaID: schemaID,
 	}, nil
 }
 
-func (p *Producer) PublishPoints(points []*v1.DataPoint) error {
+func (p *Producer) PublishPoints(ctx context.Context, points []*v1.DataPoint) error {
 	if len(points) == 0 {
 		return nil
 	}
 
+	ctx, span := tracer.Start(ctx, "kafka.PublishPoints", trace.WithAttributes(
+		attribute.Int("points.count", len(points)),
+	))
+	defer span.End()
+
 	messages := make([]kafka.Message, len(points))
 
 	for i, point := range points {
-		// Try protobuf first
-		data, err := protobuf.Marshal(point)
+		data, actualFormat, err := p.marshalPoint(point)
 		if err != nil {
-			// Fallback to JSON
-			log.Printf("⚠️  Failed to marshal protobuf, falling back to JSON: %v", err)
-			data, err = json.Marshal(point)
-			if err != nil {
-				return fmt.Errorf("failed to marshal point to JSON: %w", err)
+			return err
+		}
+
+		// The schema registered at startup describes p.format; only stamp
+		// the envelope when the payload was actually encoded that way, so a
+		// marshal fallback never ends up tagged with a mismatched schema id.
+		if p.schemaID != nil {
+			if actualFormat == p.format {
+				data = encodeConfluentEnvelope(*p.schemaID, data)
+			} else {
+				log.Printf("⚠️  Skipping Confluent envelope for %s: encoded as %s but schema was registered for %s", point.Source, actualFormat, p.format)
 			}
 		}
 
@@ -68,16 +139,17 @@ func (p *Producer) PublishPoints(points []*v1.DataPoint) error {
 				{Key: "source", Value: []byte(point.Source)},
 				{Key: "variable", Value: []byte(point.Variable)},
 				{Key: "category", Value: []byte(point.Category)},
-				{Key: "format", Value: []byte("protobuf")},
+				{Key: "format", Value: []byte(actualFormat)},
 			},
 		}
 	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
 	err := p.writer.WriteMessages(ctx, messages...)
 	if err != nil {
+		metrics.KafkaPublishErrors.Inc()
 		return fmt.Errorf("failed to write messages to Kafka: %w", err)
 	}
 
@@ -85,8 +157,33 @@ func (p *Producer) PublishPoints(points []*v1.DataPoint) error {
 	return nil
 }
 
-func (p *Producer) PublishPoint(point *v1.DataPoint) error {
-	return p.PublishPoints([]*v1.DataPoint{point})
+// marshalPoint encodes point per p.format and reports the encoding actually
+// used, which can differ from p.format when protobuf marshaling fails and
+// falls back to JSON.
+func (p *Producer) marshalPoint(point *v1.DataPoint) ([]byte, string, error) {
+	if p.format == "json" {
+		data, err := json.Marshal(point)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to marshal point to JSON: %w", err)
+		}
+		return data, "json", nil
+	}
+
+	data, err := protobuf.Marshal(point)
+	if err == nil {
+		return data, "protobuf", nil
+	}
+
+	log.Printf("⚠️  Failed to marshal protobuf, falling back to JSON: %v", err)
+	data, err = json.Marshal(point)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to marshal point to JSON: %w", err)
+	}
+	return data, "json", nil
+}
+
+func (p *Producer) PublishPoint(ctx context.Context, point *v1.DataPoint) error {
+	return p.PublishPoints(ctx, []*v1.DataPoint{point})
 }
 
 func (p *Producer) Close() error {
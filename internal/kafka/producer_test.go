@@ -0,0 +1,52 @@
+package kafka
+
+import (
+	"encoding/json"
+	"testing"
+
+	"emma/gen/go/proto/v1"
+
+	protobuf "google.golang.org/protobuf/proto"
+)
+
+func TestMarshalPointJSONFormat(t *testing.T) {
+	p := &Producer{format: "json"}
+	point := &v1.DataPoint{Source: "station-1", Variable: "temp", Value: 21.5}
+
+	data, actualFormat, err := p.marshalPoint(point)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actualFormat != "json" {
+		t.Fatalf("expected actual format json, got %s", actualFormat)
+	}
+
+	var decoded v1.DataPoint
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("expected valid JSON payload: %v", err)
+	}
+	if decoded.Source != point.Source {
+		t.Fatalf("expected source %q, got %q", point.Source, decoded.Source)
+	}
+}
+
+func TestMarshalPointProtobufFormat(t *testing.T) {
+	p := &Producer{format: "protobuf"}
+	point := &v1.DataPoint{Source: "station-1", Variable: "temp", Value: 21.5}
+
+	data, actualFormat, err := p.marshalPoint(point)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actualFormat != "protobuf" {
+		t.Fatalf("expected actual format protobuf, got %s", actualFormat)
+	}
+
+	var decoded v1.DataPoint
+	if err := protobuf.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("expected valid protobuf payload: %v", err)
+	}
+	if decoded.Source != point.Source {
+		t.Fatalf("expected source %q, got %q", point.Source, decoded.Source)
+	}
+}
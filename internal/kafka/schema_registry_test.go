@@ -0,0 +1,26 @@
+package kafka
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+func TestEncodeConfluentEnvelope(t *testing.T) {
+	payload := []byte("hello")
+	envelope := encodeConfluentEnvelope(42, payload)
+
+	if len(envelope) != 5+len(payload) {
+		t.Fatalf("expected envelope length %d, got %d", 5+len(payload), len(envelope))
+	}
+	if envelope[0] != 0x00 {
+		t.Fatalf("expected magic byte 0x00, got %#x", envelope[0])
+	}
+	gotID := binary.BigEndian.Uint32(envelope[1:5])
+	if gotID != 42 {
+		t.Fatalf("expected schema id 42, got %d", gotID)
+	}
+	if !bytes.Equal(envelope[5:], payload) {
+		t.Fatalf("expected payload to follow the 5-byte header unchanged")
+	}
+}
@@ -0,0 +1,45 @@
+// Package metrics defines the Prometheus instrumentation exposed by the
+// ingestor on /metrics.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	FetchTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "emma_fetch_total",
+		Help: "Total number of fetches per source, category and outcome; retries within one fetch count once.",
+	}, []string{"source", "category", "status"})
+
+	PointsPublishedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "emma_points_published_total",
+		Help: "Total number of DataPoints published to the configured sinks.",
+	})
+
+	KafkaPublishErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "emma_kafka_publish_errors_total",
+		Help: "Total number of errors encountered publishing to Kafka.",
+	})
+
+	FetchDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "emma_fetch_duration_seconds",
+		Help:    "Latency of a source's fetch per tick, including retries, backoff and rate-limit waits.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"source", "category"})
+
+	PublishDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "emma_publish_duration_seconds",
+		Help:    "Latency of sink.Publish calls per source.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"source", "category"})
+)
+
+// Handler returns the http.Handler to mount at /metrics.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -0,0 +1,112 @@
+package resilience
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	stateClosed breakerState = iota
+	stateOpen
+	stateHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case stateClosed:
+		return "closed"
+	case stateOpen:
+		return "open"
+	case stateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// CircuitBreaker is a per-source closed -> open -> half-open state machine.
+// It trips to open after failureThreshold consecutive failures, refuses all
+// calls until cooldown elapses, then allows a single half-open probe through;
+// that probe's result decides whether it closes again or re-opens.
+type CircuitBreaker struct {
+	name             string
+	failureThreshold int
+	cooldown         time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	openedAt time.Time
+}
+
+func NewCircuitBreaker(name string, failureThreshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{
+		name:             name,
+		failureThreshold: failureThreshold,
+		cooldown:         cooldown,
+		state:            stateClosed,
+	}
+}
+
+// Allow reports whether a call should proceed, transitioning open -> half-open
+// once the cooldown has elapsed.
+func (b *CircuitBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateClosed:
+		return true
+	case stateHalfOpen:
+		// A probe is already in flight; hold everything else back.
+		return false
+	case stateOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.transition(stateHalfOpen, "cooldown elapsed, sending probe")
+		return true
+	default:
+		return false
+	}
+}
+
+func (b *CircuitBreaker) RecordSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != stateClosed {
+		b.transition(stateClosed, "probe succeeded")
+	}
+	b.failures = 0
+}
+
+func (b *CircuitBreaker) RecordFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateHalfOpen:
+		b.trip("probe failed")
+	case stateClosed:
+		b.failures++
+		if b.failures >= b.failureThreshold {
+			b.trip(fmt.Sprintf("%d consecutive failures", b.failures))
+		}
+	}
+}
+
+func (b *CircuitBreaker) trip(reason string) {
+	b.transition(stateOpen, reason)
+	b.openedAt = time.Now()
+	b.failures = 0
+}
+
+func (b *CircuitBreaker) transition(to breakerState, reason string) {
+	log.Printf("circuit breaker %s: %s -> %s (%s)", b.name, b.state, to, reason)
+	b.state = to
+}
@@ -0,0 +1,81 @@
+package resilience
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCircuitBreakerTripsAfterThreshold(t *testing.T) {
+	b := NewCircuitBreaker("test", 3, time.Minute)
+
+	for i := 0; i < 2; i++ {
+		if !b.Allow() {
+			t.Fatalf("expected Allow() to be true before threshold is reached")
+		}
+		b.RecordFailure()
+	}
+	if b.state != stateClosed {
+		t.Fatalf("expected breaker to stay closed below threshold, got %s", b.state)
+	}
+
+	if !b.Allow() {
+		t.Fatalf("expected Allow() to be true for the threshold-reaching attempt")
+	}
+	b.RecordFailure()
+	if b.state != stateOpen {
+		t.Fatalf("expected breaker to trip open at threshold, got %s", b.state)
+	}
+	if b.Allow() {
+		t.Fatalf("expected Allow() to be false immediately after tripping")
+	}
+}
+
+func TestCircuitBreakerHalfOpenProbe(t *testing.T) {
+	b := NewCircuitBreaker("test", 1, 10*time.Millisecond)
+
+	b.RecordFailure()
+	if b.state != stateOpen {
+		t.Fatalf("expected breaker to trip open after 1 failure, got %s", b.state)
+	}
+
+	time.Sleep(20 * time.Millisecond)
+
+	if !b.Allow() {
+		t.Fatalf("expected Allow() to admit a probe after cooldown elapses")
+	}
+	if b.state != stateHalfOpen {
+		t.Fatalf("expected breaker to move to half-open on probe, got %s", b.state)
+	}
+	if b.Allow() {
+		t.Fatalf("expected Allow() to refuse a second call while a probe is in flight")
+	}
+}
+
+func TestCircuitBreakerHalfOpenSuccessCloses(t *testing.T) {
+	b := NewCircuitBreaker("test", 1, 10*time.Millisecond)
+
+	b.RecordFailure()
+	time.Sleep(20 * time.Millisecond)
+	b.Allow()
+
+	b.RecordSuccess()
+	if b.state != stateClosed {
+		t.Fatalf("expected breaker to close after a successful probe, got %s", b.state)
+	}
+	if !b.Allow() {
+		t.Fatalf("expected Allow() to be true once closed again")
+	}
+}
+
+func TestCircuitBreakerHalfOpenFailureReopens(t *testing.T) {
+	b := NewCircuitBreaker("test", 1, 10*time.Millisecond)
+
+	b.RecordFailure()
+	time.Sleep(20 * time.Millisecond)
+	b.Allow()
+
+	b.RecordFailure()
+	if b.state != stateOpen {
+		t.Fatalf("expected breaker to re-open after a failed probe, got %s", b.state)
+	}
+}
@@ -0,0 +1,153 @@
+// Package resilience bundles the per-source retry/backoff, rate limiting,
+// and circuit breaker policies applied around a fetch call.
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand"
+	"time"
+
+	"emma/internal/config"
+	"emma/internal/handlers"
+
+	"golang.org/x/time/rate"
+)
+
+// Policy wraps a fetch call with rate limiting, exponential backoff with
+// full jitter, and a circuit breaker. Any of the three may be nil/disabled,
+// in which case that aspect is a no-op.
+type Policy struct {
+	name string
+
+	limiter *rate.Limiter
+	breaker *CircuitBreaker
+
+	maxAttempts    int
+	initialBackoff time.Duration
+	maxBackoff     time.Duration
+	jitter         bool
+}
+
+// NewPolicy builds a Policy from a source's optional retry/rate_limit/
+// circuit_breaker configuration. A source with none of the three configured
+// gets a Policy that makes exactly one attempt with no limiting.
+func NewPolicy(name string, retry *config.RetryConfig, rateLimit *config.RateLimitConfig, breaker *config.CircuitBreakerConfig) (*Policy, error) {
+	p := &Policy{
+		name:        name,
+		maxAttempts: 1,
+	}
+
+	if retry != nil {
+		initialBackoff, err := retry.InitialBackoffDuration()
+		if err != nil {
+			return nil, fmt.Errorf("invalid initial_backoff: %w", err)
+		}
+		maxBackoff, err := retry.MaxBackoffDuration()
+		if err != nil {
+			return nil, fmt.Errorf("invalid max_backoff: %w", err)
+		}
+		p.maxAttempts = retry.MaxAttempts
+		p.initialBackoff = initialBackoff
+		p.maxBackoff = maxBackoff
+		p.jitter = retry.Jitter
+	}
+
+	if rateLimit != nil {
+		p.limiter = rate.NewLimiter(rate.Limit(rateLimit.RPS), rateLimit.Burst)
+	}
+
+	if breaker != nil {
+		cooldown, err := breaker.CooldownDuration()
+		if err != nil {
+			return nil, fmt.Errorf("invalid circuit_breaker.cooldown: %w", err)
+		}
+		p.breaker = NewCircuitBreaker(name, breaker.FailureThreshold, cooldown)
+	}
+
+	return p, nil
+}
+
+// ErrCircuitOpen is returned when a call is skipped because the breaker is open.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// Run executes fn, retrying on failure per the configured backoff, honoring
+// any Retry-After hint from a handlers.RetryableError, and reporting outcomes
+// to the circuit breaker. It returns ErrCircuitOpen without calling fn if the
+// breaker is currently open.
+func (p *Policy) Run(ctx context.Context, fn func() error) error {
+	backoff := p.initialBackoff
+	var lastErr error
+
+	for attempt := 1; attempt <= p.maxAttempts; attempt++ {
+		if p.breaker != nil && !p.breaker.Allow() {
+			if lastErr != nil {
+				return fmt.Errorf("%s: %w (last error: %v)", p.name, ErrCircuitOpen, lastErr)
+			}
+			return fmt.Errorf("%s: %w", p.name, ErrCircuitOpen)
+		}
+
+		if p.limiter != nil {
+			if err := p.limiter.Wait(ctx); err != nil {
+				return fmt.Errorf("%s: rate limiter wait: %w", p.name, err)
+			}
+		}
+
+		err := fn()
+		if err == nil {
+			if p.breaker != nil {
+				p.breaker.RecordSuccess()
+			}
+			return nil
+		}
+
+		lastErr = err
+		if p.breaker != nil {
+			p.breaker.RecordFailure()
+		}
+
+		if attempt == p.maxAttempts {
+			break
+		}
+
+		wait := backoff
+		if p.jitter {
+			wait = fullJitter(wait)
+		}
+		if p.maxBackoff > 0 && wait > p.maxBackoff {
+			wait = p.maxBackoff
+		}
+		// An upstream Retry-After replaces the backoff schedule outright: it
+		// is neither jittered nor capped at max_backoff.
+		var retryable *handlers.RetryableError
+		if errors.As(err, &retryable) && retryable.RetryAfter > 0 {
+			wait = retryable.RetryAfter
+		}
+
+		log.Printf("%s: attempt %d/%d failed (%v), retrying in %s", p.name, attempt, p.maxAttempts, err, wait)
+
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		backoff *= 2
+		if p.maxBackoff > 0 && backoff > p.maxBackoff {
+			backoff = p.maxBackoff
+		}
+	}
+
+	return lastErr
+}
+
+// fullJitter picks a random duration in [0, d), per the AWS "full jitter"
+// backoff strategy.
+func fullJitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d)))
+}
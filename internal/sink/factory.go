@@ -0,0 +1,23 @@
+package sink
+
+import (
+	"fmt"
+
+	"emma/internal/config"
+)
+
+// New builds the Sink for a single sink configuration entry.
+func New(cfg config.SinkConfig) (Sink, error) {
+	switch cfg.Type {
+	case "kafka":
+		return NewKafkaSink(cfg.Config)
+	case "influxdb":
+		return NewInfluxSink(cfg.Config)
+	case "minio_parquet":
+		return NewParquetSink(cfg.Config)
+	case "stdout":
+		return NewStdoutSink(), nil
+	default:
+		return nil, fmt.Errorf("unknown sink type: %s", cfg.Type)
+	}
+}
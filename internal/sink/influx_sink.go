@@ -0,0 +1,74 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"emma/gen/go/proto/v1"
+
+	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
+	"github.com/influxdata/influxdb-client-go/v2/api"
+)
+
+// InfluxSink writes points to InfluxDB v2 using line protocol.
+type InfluxSink struct {
+	client   influxdb2.Client
+	writeAPI api.WriteAPIBlocking
+}
+
+func NewInfluxSink(cfg map[string]any) (*InfluxSink, error) {
+	url, ok := cfg["url"].(string)
+	if !ok || url == "" {
+		return nil, fmt.Errorf("url is required for influxdb sink")
+	}
+	token, ok := cfg["token"].(string)
+	if !ok || token == "" {
+		return nil, fmt.Errorf("token is required for influxdb sink")
+	}
+	org, ok := cfg["org"].(string)
+	if !ok || org == "" {
+		return nil, fmt.Errorf("org is required for influxdb sink")
+	}
+	bucket, ok := cfg["bucket"].(string)
+	if !ok || bucket == "" {
+		return nil, fmt.Errorf("bucket is required for influxdb sink")
+	}
+
+	client := influxdb2.NewClient(url, token)
+	writeAPI := client.WriteAPIBlocking(org, bucket)
+
+	return &InfluxSink{client: client, writeAPI: writeAPI}, nil
+}
+
+func (s *InfluxSink) Publish(ctx context.Context, points []*v1.DataPoint) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	for _, point := range points {
+		p := influxdb2.NewPoint(
+			point.Variable,
+			map[string]string{
+				"source":   point.Source,
+				"category": point.Category,
+				"units":    point.Units,
+			},
+			map[string]interface{}{
+				"value": point.Value,
+				"lat":   point.Lat,
+				"lon":   point.Lon,
+			},
+			time.UnixMilli(point.EpochMs),
+		)
+		if err := s.writeAPI.WritePoint(ctx, p); err != nil {
+			return fmt.Errorf("failed to write point to influxdb: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *InfluxSink) Close() error {
+	s.client.Close()
+	return nil
+}
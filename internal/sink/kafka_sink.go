@@ -0,0 +1,58 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"emma/gen/go/proto/v1"
+	"emma/internal/kafka"
+)
+
+// KafkaSink publishes points to Kafka via the existing protobuf producer.
+type KafkaSink struct {
+	producer *kafka.Producer
+}
+
+func NewKafkaSink(cfg map[string]any) (*KafkaSink, error) {
+	brokersRaw, ok := cfg["brokers"].(string)
+	if !ok || brokersRaw == "" {
+		return nil, fmt.Errorf("brokers is required for kafka sink")
+	}
+	topic, ok := cfg["topic"].(string)
+	if !ok || topic == "" {
+		return nil, fmt.Errorf("topic is required for kafka sink")
+	}
+
+	schemaRegistryAuth, _ := cfg["schema_registry_auth"].(string)
+	if strings.HasPrefix(schemaRegistryAuth, "${") && strings.HasSuffix(schemaRegistryAuth, "}") {
+		envVar := strings.TrimPrefix(strings.TrimSuffix(schemaRegistryAuth, "}"), "${")
+		schemaRegistryAuth = os.Getenv(envVar)
+	}
+	serializationFormat, _ := cfg["serialization_format"].(string)
+	schemaRegistryURL, _ := cfg["schema_registry_url"].(string)
+	failFast, _ := cfg["schema_registry_fail_fast"].(bool)
+
+	producer, err := kafka.NewProducer(kafka.ProducerConfig{
+		Brokers:                strings.Split(brokersRaw, ","),
+		Topic:                  topic,
+		SchemaRegistryURL:      schemaRegistryURL,
+		SchemaRegistryAuth:     schemaRegistryAuth,
+		SerializationFormat:    serializationFormat,
+		SchemaRegistryFailFast: failFast,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kafka producer: %w", err)
+	}
+
+	return &KafkaSink{producer: producer}, nil
+}
+
+func (s *KafkaSink) Publish(ctx context.Context, points []*v1.DataPoint) error {
+	return s.producer.PublishPoints(ctx, points)
+}
+
+func (s *KafkaSink) Close() error {
+	return s.producer.Close()
+}
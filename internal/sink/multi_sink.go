@@ -0,0 +1,54 @@
+package sink
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"emma/gen/go/proto/v1"
+)
+
+// namedSink pairs a Sink with the name it was configured under, so per-sink
+// failures in MultiSink can be attributed to the right destination.
+type namedSink struct {
+	name string
+	Sink
+}
+
+// MultiSink fans a batch of points out to every configured sink. A failure
+// in one sink is logged and isolated; it does not stop delivery to the rest.
+type MultiSink struct {
+	sinks []namedSink
+}
+
+// NewMultiSink builds a MultiSink from a name->Sink map, preserving fan-out
+// order isn't required since every sink receives the same batch independently.
+func NewMultiSink(sinks map[string]Sink) *MultiSink {
+	named := make([]namedSink, 0, len(sinks))
+	for name, s := range sinks {
+		named = append(named, namedSink{name: name, Sink: s})
+	}
+	return &MultiSink{sinks: named}
+}
+
+func (m *MultiSink) Publish(ctx context.Context, points []*v1.DataPoint) error {
+	var errs []error
+	for _, s := range m.sinks {
+		if err := s.Publish(ctx, points); err != nil {
+			log.Printf("⚠️  sink %s failed to publish %d points: %v", s.name, len(points), err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (m *MultiSink) Close() error {
+	var errs []error
+	for _, s := range m.sinks {
+		if err := s.Close(); err != nil {
+			log.Printf("⚠️  sink %s failed to close: %v", s.name, err)
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
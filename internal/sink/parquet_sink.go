@@ -0,0 +1,216 @@
+package sink
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"emma/gen/go/proto/v1"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+// parquetRow is the on-disk row shape written to each parquet file.
+type parquetRow struct {
+	Source     string  `parquet:"name=source, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Category   string  `parquet:"name=category, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Variable   string  `parquet:"name=variable, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Units      string  `parquet:"name=units, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Value      float64 `parquet:"name=value, type=DOUBLE"`
+	Lat        float64 `parquet:"name=lat, type=DOUBLE"`
+	Lon        float64 `parquet:"name=lon, type=DOUBLE"`
+	EpochMs    int64   `parquet:"name=epoch_ms, type=INT64"`
+	Uuid       string  `parquet:"name=uuid, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Resolution string  `parquet:"name=resolution, type=BYTE_ARRAY, convertedtype=UTF8"`
+}
+
+// ParquetSink batches points per source/category and uploads them to a
+// MinIO/S3 bucket as parquet files once a batch reaches maxBatchSize points
+// or flushInterval elapses, whichever comes first.
+type ParquetSink struct {
+	client        *minio.Client
+	bucket        string
+	maxBatchSize  int
+	flushInterval time.Duration
+
+	mu      sync.Mutex
+	batches map[string][]*v1.DataPoint
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewParquetSink(cfg map[string]any) (*ParquetSink, error) {
+	endpoint, ok := cfg["endpoint"].(string)
+	if !ok || endpoint == "" {
+		return nil, fmt.Errorf("endpoint is required for minio_parquet sink")
+	}
+	accessKey, _ := cfg["access_key"].(string)
+	secretKey, _ := cfg["secret_key"].(string)
+	bucket, ok := cfg["bucket"].(string)
+	if !ok || bucket == "" {
+		return nil, fmt.Errorf("bucket is required for minio_parquet sink")
+	}
+	useSSL, _ := cfg["use_ssl"].(bool)
+
+	maxBatchSize := 1000
+	if v, ok := cfg["batch_size"].(int); ok && v > 0 {
+		maxBatchSize = v
+	}
+	flushInterval := 60 * time.Second
+	if v, ok := cfg["flush_interval"].(string); ok && v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid flush_interval %q: %w", v, err)
+		}
+		flushInterval = d
+	}
+
+	client, err := minio.New(endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(accessKey, secretKey, ""),
+		Secure: useSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create minio client: %w", err)
+	}
+
+	s := &ParquetSink{
+		client:        client,
+		bucket:        bucket,
+		maxBatchSize:  maxBatchSize,
+		flushInterval: flushInterval,
+		batches:       make(map[string][]*v1.DataPoint),
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+	}
+
+	go s.flushLoop()
+
+	return s, nil
+}
+
+func (s *ParquetSink) flushLoop() {
+	defer close(s.done)
+
+	ticker := time.NewTicker(s.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flushAll()
+		case <-s.stop:
+			s.flushAll()
+			return
+		}
+	}
+}
+
+func (s *ParquetSink) Publish(ctx context.Context, points []*v1.DataPoint) error {
+	s.mu.Lock()
+	var toFlush map[string][]*v1.DataPoint
+
+	for _, point := range points {
+		key := batchKey(point.Category, point.Source)
+		s.batches[key] = append(s.batches[key], point)
+		if len(s.batches[key]) >= s.maxBatchSize {
+			if toFlush == nil {
+				toFlush = make(map[string][]*v1.DataPoint)
+			}
+			toFlush[key] = s.batches[key]
+			delete(s.batches, key)
+		}
+	}
+	s.mu.Unlock()
+
+	var errs []error
+	for key, batch := range toFlush {
+		if err := s.writeBatch(ctx, key, batch); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to flush %d batch(es), first error: %w", len(errs), errs[0])
+	}
+	return nil
+}
+
+func (s *ParquetSink) flushAll() {
+	s.mu.Lock()
+	toFlush := s.batches
+	s.batches = make(map[string][]*v1.DataPoint)
+	s.mu.Unlock()
+
+	for key, batch := range toFlush {
+		if len(batch) == 0 {
+			continue
+		}
+		if err := s.writeBatch(context.Background(), key, batch); err != nil {
+			fmt.Printf("⚠️  parquet sink: failed to flush batch %s: %v\n", key, err)
+		}
+	}
+}
+
+func (s *ParquetSink) writeBatch(ctx context.Context, key string, points []*v1.DataPoint) error {
+	fw := buffer.NewBufferFile()
+	pw, err := writer.NewParquetWriter(fw, new(parquetRow), 4)
+	if err != nil {
+		return fmt.Errorf("failed to create parquet writer: %w", err)
+	}
+
+	for _, point := range points {
+		row := parquetRow{
+			Source:     point.Source,
+			Category:   point.Category,
+			Variable:   point.Variable,
+			Units:      point.Units,
+			Value:      point.Value,
+			Lat:        point.Lat,
+			Lon:        point.Lon,
+			EpochMs:    point.EpochMs,
+			Uuid:       point.Uuid,
+			Resolution: point.Resolution,
+		}
+		if err := pw.Write(row); err != nil {
+			return fmt.Errorf("failed to write row: %w", err)
+		}
+	}
+
+	if err := pw.WriteStop(); err != nil {
+		return fmt.Errorf("failed to finalize parquet file: %w", err)
+	}
+
+	data := fw.Bytes()
+	objectName := fmt.Sprintf("%s/%s.parquet", key, generateBatchID())
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	_, err = s.client.PutObject(ctx, s.bucket, objectName, bytes.NewReader(data), int64(len(data)), minio.PutObjectOptions{
+		ContentType: "application/octet-stream",
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload %s: %w", objectName, err)
+	}
+
+	return nil
+}
+
+func (s *ParquetSink) Close() error {
+	close(s.stop)
+	<-s.done
+	return nil
+}
+
+func batchKey(category, source string) string {
+	return fmt.Sprintf("category=%s/source=%s/dt=%s", category, source, time.Now().UTC().Format("2006-01-02"))
+}
+
+func generateBatchID() string {
+	return fmt.Sprintf("%d", time.Now().UnixNano())
+}
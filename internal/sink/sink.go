@@ -0,0 +1,15 @@
+// Package sink defines the pluggable output destinations that published
+// DataPoints can be fanned out to (Kafka, InfluxDB, MinIO/S3 parquet, stdout).
+package sink
+
+import (
+	"context"
+
+	"emma/gen/go/proto/v1"
+)
+
+// Sink is an output destination for a batch of DataPoints.
+type Sink interface {
+	Publish(ctx context.Context, points []*v1.DataPoint) error
+	Close() error
+}
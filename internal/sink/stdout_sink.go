@@ -0,0 +1,27 @@
+package sink
+
+import (
+	"context"
+	"fmt"
+
+	"emma/gen/go/proto/v1"
+)
+
+// StdoutSink prints each point to stdout, useful for local debugging.
+type StdoutSink struct{}
+
+func NewStdoutSink() *StdoutSink {
+	return &StdoutSink{}
+}
+
+func (s *StdoutSink) Publish(ctx context.Context, points []*v1.DataPoint) error {
+	for _, point := range points {
+		fmt.Printf("📊 %s (%s): %s = %.2f %s at (%.4f, %.4f)\n",
+			point.Source, point.Category, point.Variable, point.Value, point.Units, point.Lat, point.Lon)
+	}
+	return nil
+}
+
+func (s *StdoutSink) Close() error {
+	return nil
+}
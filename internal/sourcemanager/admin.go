@@ -0,0 +1,87 @@
+package sourcemanager
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// sourceStatus is the JSON shape returned by GET /sources.
+type sourceStatus struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Category string `json:"category"`
+	Paused   bool   `json:"paused"`
+}
+
+// AdminHandler exposes per-source lifecycle control over HTTP:
+//
+//	GET  /sources                 list sources and their pause state
+//	POST /sources/{name}/pause    quiesce a source, leaving its worker running
+//	POST /sources/{name}/resume   resume a paused source
+//	POST /sources/{name}/fetch-now  trigger an out-of-band fetch immediately
+func (m *Manager) AdminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /sources", m.handleList)
+	mux.HandleFunc("POST /sources/{name}/pause", m.handlePause)
+	mux.HandleFunc("POST /sources/{name}/resume", m.handleResume)
+	mux.HandleFunc("POST /sources/{name}/fetch-now", m.handleFetchNow)
+	return mux
+}
+
+func (m *Manager) handleList(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	statuses := make([]sourceStatus, 0, len(m.workers))
+	for _, wk := range m.workers {
+		statuses = append(statuses, sourceStatus{
+			Name:     wk.source.Name,
+			Type:     wk.source.Type,
+			Category: wk.source.Category,
+			Paused:   wk.paused.Load(),
+		})
+	}
+	m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(statuses); err != nil {
+		http.Error(w, fmt.Sprintf("failed to encode response: %v", err), http.StatusInternalServerError)
+	}
+}
+
+func (m *Manager) handlePause(w http.ResponseWriter, r *http.Request) {
+	m.withWorker(w, r, func(wk *worker) {
+		wk.paused.Store(true)
+	})
+}
+
+func (m *Manager) handleResume(w http.ResponseWriter, r *http.Request) {
+	m.withWorker(w, r, func(wk *worker) {
+		wk.paused.Store(false)
+	})
+}
+
+func (m *Manager) handleFetchNow(w http.ResponseWriter, r *http.Request) {
+	m.withWorker(w, r, func(wk *worker) {
+		select {
+		case wk.fetchNow <- struct{}{}:
+		default:
+			// a fetch is already pending; no need to queue another
+		}
+	})
+}
+
+func (m *Manager) withWorker(w http.ResponseWriter, r *http.Request, fn func(*worker)) {
+	name := r.PathValue("name")
+
+	m.mu.Lock()
+	wk, ok := m.workers[name]
+	m.mu.Unlock()
+
+	if !ok {
+		http.Error(w, fmt.Sprintf("unknown source %q", name), http.StatusNotFound)
+		return
+	}
+
+	fn(wk)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,326 @@
+// Package sourcemanager hot-reloads source configs from ./sources/ and
+// manages the lifecycle of the worker goroutine backing each one, so that
+// sources can be added, edited, or removed without restarting the ingestor.
+package sourcemanager
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"emma/gen/go/proto/v1"
+	"emma/internal/config"
+	"emma/internal/handlers"
+	"emma/internal/metrics"
+	"emma/internal/resilience"
+	"emma/internal/sink"
+)
+
+var tracer = otel.Tracer("emma/internal/sourcemanager")
+
+// reloadDebounce coalesces bursts of filesystem events (e.g. an editor
+// writing via a temp file + rename) into a single reload.
+const reloadDebounce = 500 * time.Millisecond
+
+// Manager watches a directory of source configs and keeps one worker
+// goroutine running per source, starting, restarting, or stopping workers
+// as configs are added, changed, or removed on disk.
+type Manager struct {
+	dir string
+	out sink.Sink
+
+	mu      sync.Mutex
+	workers map[string]*worker
+}
+
+// worker tracks the running goroutine for a single source.
+type worker struct {
+	source config.SourceConfig
+	hash   string
+	cancel context.CancelFunc
+	paused atomic.Bool
+	// fetchNow triggers an out-of-band fetch outside the regular ticker.
+	fetchNow chan struct{}
+	done     chan struct{}
+}
+
+// New builds a Manager that will load and watch dir, publishing fetched
+// points to out.
+func New(dir string, out sink.Sink) *Manager {
+	return &Manager{
+		dir:     dir,
+		out:     out,
+		workers: make(map[string]*worker),
+	}
+}
+
+// Start performs the initial load of dir and begins watching it for
+// changes. It returns an error if the initial load fails or the directory
+// cannot be watched; reload errors after that are logged and leave existing
+// workers running.
+func (m *Manager) Start() error {
+	if err := m.reload(); err != nil {
+		return fmt.Errorf("initial source load failed: %w", err)
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to create fsnotify watcher: %w", err)
+	}
+	if err := watcher.Add(m.dir); err != nil {
+		return fmt.Errorf("failed to watch %s: %w", m.dir, err)
+	}
+
+	go m.watch(watcher)
+
+	return nil
+}
+
+// Stop cancels every running worker and waits for them to exit.
+func (m *Manager) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for name := range m.workers {
+		m.stopWorkerLocked(name)
+	}
+}
+
+func (m *Manager) watch(watcher *fsnotify.Watcher) {
+	defer watcher.Close()
+
+	var debounce *time.Timer
+	scheduleReload := func() {
+		if debounce != nil {
+			debounce.Stop()
+		}
+		debounce = time.AfterFunc(reloadDebounce, func() {
+			if err := m.reload(); err != nil {
+				log.Printf("⚠️  Failed to reload source configs, keeping existing workers: %v", err)
+			}
+		})
+	}
+
+	for {
+		select {
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			scheduleReload()
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("⚠️  fsnotify watcher error: %v", err)
+		}
+	}
+}
+
+// reload diffs the configs on disk against running workers by name+hash,
+// starting, restarting, or stopping workers as needed. If the configs on
+// disk fail to load or validate, the error is returned without touching any
+// currently running worker.
+func (m *Manager) reload() error {
+	sources, err := config.LoadSourceConfigs(m.dir)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool, len(sources))
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, source := range sources {
+		seen[source.Name] = true
+
+		hash, err := hashSource(source)
+		if err != nil {
+			log.Printf("⚠️  Failed to hash source %s, skipping: %v", source.Name, err)
+			continue
+		}
+
+		existing, hasExisting := m.workers[source.Name]
+		if hasExisting && existing.hash == hash {
+			continue
+		}
+
+		w, err := m.startWorkerLocked(source, hash)
+		if err != nil {
+			log.Printf("⚠️  Failed to start worker for %s, keeping existing worker running: %v", source.Name, err)
+			continue
+		}
+
+		if hasExisting {
+			log.Printf("🔁 Config changed for source %s, restarting worker", source.Name)
+			m.stopWorkerLocked(source.Name)
+		} else {
+			log.Printf("➕ New source %s, starting worker", source.Name)
+		}
+		m.workers[source.Name] = w
+	}
+
+	for name := range m.workers {
+		if !seen[name] {
+			log.Printf("➖ Source %s removed, stopping worker", name)
+			m.stopWorkerLocked(name)
+		}
+	}
+
+	return nil
+}
+
+func hashSource(source config.SourceConfig) (string, error) {
+	data, err := json.Marshal(source)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash source config: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// startWorkerLocked must be called with m.mu held.
+func (m *Manager) startWorkerLocked(source config.SourceConfig, hash string) (*worker, error) {
+	handler, err := handlers.GetHandler(source.Type)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get handler for %s: %w", source.Name, err)
+	}
+	if err := handler.Validate(source.Config); err != nil {
+		return nil, fmt.Errorf("invalid config for %s: %w", source.Name, err)
+	}
+	frequency, err := source.GetFrequency()
+	if err != nil {
+		return nil, fmt.Errorf("invalid frequency for %s: %w", source.Name, err)
+	}
+	policy, err := resilience.NewPolicy(source.Name, source.Retry, source.RateLimit, source.CircuitBreaker)
+	if err != nil {
+		return nil, fmt.Errorf("invalid resilience policy for %s: %w", source.Name, err)
+	}
+
+	sourceConfig := make(map[string]interface{}, len(source.Config)+2)
+	for k, v := range source.Config {
+		sourceConfig[k] = v
+	}
+	sourceConfig["category"] = source.Category
+	sourceConfig["source"] = source.Name
+
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &worker{
+		source:   source,
+		hash:     hash,
+		cancel:   cancel,
+		fetchNow: make(chan struct{}, 1),
+		done:     make(chan struct{}),
+	}
+
+	go m.run(ctx, w, handler, sourceConfig, policy, frequency)
+
+	return w, nil
+}
+
+// stopWorkerLocked must be called with m.mu held.
+func (m *Manager) stopWorkerLocked(name string) {
+	w, ok := m.workers[name]
+	if !ok {
+		return
+	}
+	w.cancel()
+	<-w.done
+	delete(m.workers, name)
+}
+
+func (m *Manager) run(ctx context.Context, w *worker, handler handlers.Handler, cfg map[string]interface{}, policy *resilience.Policy, frequency time.Duration) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(frequency)
+	defer ticker.Stop()
+
+	tick := func() {
+		defer func() {
+			if r := recover(); r != nil {
+				log.Printf("⚠️  Recovered from panic while fetching source %s: %v", w.source.Name, r)
+			}
+		}()
+		fetchAndPublish(ctx, w.source, handler, cfg, m.out, policy)
+	}
+
+	if !w.paused.Load() {
+		tick()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !w.paused.Load() {
+				tick()
+			}
+		case <-w.fetchNow:
+			tick()
+		}
+	}
+}
+
+func fetchAndPublish(ctx context.Context, source config.SourceConfig, handler handlers.Handler, cfg map[string]interface{}, out sink.Sink, policy *resilience.Policy) {
+	sourceName := source.Name
+	log.Printf("Fetching data for source: %s", sourceName)
+
+	ctx, span := tracer.Start(ctx, "ingestor.tick", trace.WithAttributes(
+		attribute.String("source.name", sourceName),
+		attribute.String("source.category", source.Category),
+		attribute.String("handler.type", source.Type),
+	))
+	defer span.End()
+
+	if url, ok := cfg["url"].(string); ok {
+		span.SetAttributes(attribute.String("http.url", url))
+	}
+
+	fetchStart := time.Now()
+	var points []*v1.DataPoint
+	err := policy.Run(ctx, func() error {
+		fetched, fetchErr := handler.Fetch(ctx, cfg)
+		points = fetched
+		return fetchErr
+	})
+	metrics.FetchDuration.WithLabelValues(sourceName, source.Category).Observe(time.Since(fetchStart).Seconds())
+	if err != nil {
+		metrics.FetchTotal.WithLabelValues(sourceName, source.Category, "error").Inc()
+		log.Printf("Error fetching data for %s: %v", sourceName, err)
+		return
+	}
+	metrics.FetchTotal.WithLabelValues(sourceName, source.Category, "success").Inc()
+
+	span.SetAttributes(attribute.Int("points.count", len(points)))
+
+	if len(points) == 0 {
+		log.Printf("No data points received for %s", sourceName)
+		return
+	}
+
+	log.Printf("Successfully fetched %d data points for %s", len(points), sourceName)
+
+	publishStart := time.Now()
+	err = out.Publish(ctx, points)
+	metrics.PublishDuration.WithLabelValues(sourceName, source.Category).Observe(time.Since(publishStart).Seconds())
+	if err != nil {
+		log.Printf("Failed to publish data for %s: %v", sourceName, err)
+		return
+	}
+	metrics.PointsPublishedTotal.Add(float64(len(points)))
+
+	log.Printf("✅ Successfully published %d points for %s", len(points), sourceName)
+}